@@ -0,0 +1,44 @@
+package audit
+
+import (
+    "encoding/json"
+    "log/slog"
+    "net/http"
+
+    "gorm.io/datatypes"
+
+    "go-docs-backend/db"
+    "go-docs-backend/models"
+    "go-docs-backend/requestid"
+)
+
+const (
+    ActionCreate = "create"
+    ActionUpdate = "update"
+    ActionDelete = "delete"
+)
+
+// Record persiste una entrada de auditoría para una mutación sobre un
+// documento, incluyendo un diff antes/después. before o after pueden
+// quedar en nil (before en un create, after en un delete).
+func Record(r *http.Request, userID uint, action string, documentID uint, before, after interface{}) {
+    diff, err := json.Marshal(map[string]interface{}{"before": before, "after": after})
+    if err != nil {
+        slog.ErrorContext(r.Context(), "audit: error serializando diff", "error", err, "document_id", documentID)
+        return
+    }
+
+    entry := models.DocumentAuditLog{
+        UserID:     userID,
+        Action:     action,
+        DocumentID: documentID,
+        Diff:       datatypes.JSON(diff),
+        RequestID:  requestid.FromContext(r.Context()),
+        IP:         r.RemoteAddr,
+        UserAgent:  r.UserAgent(),
+    }
+
+    if err := db.DB.Create(&entry).Error; err != nil {
+        slog.ErrorContext(r.Context(), "audit: error guardando entrada", "error", err, "document_id", documentID, "action", action)
+    }
+}
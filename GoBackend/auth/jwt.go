@@ -1,6 +1,8 @@
 package auth
 
 import (
+    "crypto/rand"
+    "encoding/hex"
     "errors"
     "os"
     "time"
@@ -10,18 +12,32 @@ import (
 
 var secretKey = []byte(getEnvOrDefault("JWT_SECRET", "your-secret-key"))
 
+// AccessTokenTTL es la vigencia del access token. Se mantiene corta a
+// propósito: la sesión se extiende canjeando el refresh token (ver
+// refresh.go), y un access token revocado antes de expirar se rechaza
+// por su jti (ver revocation.go).
+const AccessTokenTTL = 15 * time.Minute
+
 type Claims struct {
-    UserID uint `json:"user_id"`
+    UserID uint   `json:"user_id"`
+    Role   string `json:"role"`
     jwt.RegisteredClaims
 }
 
-func GenerateJWT(userID uint) (string, error) {
-    expirationTime := time.Now().Add(24 * time.Hour)
+func GenerateJWT(userID uint, role string) (string, error) {
+    jti, err := newJTI()
+    if err != nil {
+        return "", err
+    }
+
+    now := time.Now()
     claims := &Claims{
         UserID: userID,
+        Role:   role,
         RegisteredClaims: jwt.RegisteredClaims{
-            ExpiresAt: jwt.NewNumericDate(expirationTime),
-            IssuedAt:  jwt.NewNumericDate(time.Now()),
+            ID:        jti,
+            ExpiresAt: jwt.NewNumericDate(now.Add(AccessTokenTTL)),
+            IssuedAt:  jwt.NewNumericDate(now),
         },
     }
 
@@ -29,6 +45,14 @@ func GenerateJWT(userID uint) (string, error) {
     return token.SignedString(secretKey)
 }
 
+func newJTI() (string, error) {
+    buf := make([]byte, 16)
+    if _, err := rand.Read(buf); err != nil {
+        return "", err
+    }
+    return hex.EncodeToString(buf), nil
+}
+
 func ValidateJWT(tokenString string) (*Claims, error) {
     claims := &Claims{}
     token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
@@ -43,6 +67,10 @@ func ValidateJWT(tokenString string) (*Claims, error) {
         return nil, errors.New("invalid token")
     }
 
+    if isRevoked(claims.ID) {
+        return nil, errors.New("token revocado")
+    }
+
     return claims, nil
 }
 
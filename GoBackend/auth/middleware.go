@@ -1,10 +1,15 @@
 package auth
 
 import (
+    "context"
     "net/http"
     "strings"
 )
 
+type contextKey string
+
+const claimsContextKey contextKey = "claims"
+
 func AuthMiddleware(next http.Handler) http.Handler {
     return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
         token := r.Header.Get("Authorization")
@@ -14,12 +19,41 @@ func AuthMiddleware(next http.Handler) http.Handler {
         }
 
         token = strings.TrimPrefix(token, "Bearer ")
-        _, err := ValidateJWT(token)
+        claims, err := ValidateJWT(token)
         if err != nil {
             http.Error(w, "Token inválido", http.StatusUnauthorized)
             return
         }
 
-        next.ServeHTTP(w, r)
+        ctx := context.WithValue(r.Context(), claimsContextKey, claims)
+        next.ServeHTTP(w, r.WithContext(ctx))
     })
 }
+
+// RequireRole devuelve un middleware que solo permite continuar a usuarios
+// cuyo rol coincida con alguno de los indicados. Debe montarse después de
+// AuthMiddleware.
+func RequireRole(roles ...string) func(http.Handler) http.Handler {
+    return func(next http.Handler) http.Handler {
+        return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+            claims, ok := ClaimsFromContext(r.Context())
+            if !ok {
+                http.Error(w, "Permisos insuficientes", http.StatusForbidden)
+                return
+            }
+            for _, role := range roles {
+                if claims.Role == role {
+                    next.ServeHTTP(w, r)
+                    return
+                }
+            }
+            http.Error(w, "Permisos insuficientes", http.StatusForbidden)
+        })
+    }
+}
+
+// ClaimsFromContext recupera las claims del JWT inyectadas por AuthMiddleware.
+func ClaimsFromContext(ctx context.Context) (*Claims, bool) {
+    claims, ok := ctx.Value(claimsContextKey).(*Claims)
+    return claims, ok
+}
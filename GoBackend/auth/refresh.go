@@ -0,0 +1,32 @@
+package auth
+
+import (
+    "crypto/rand"
+    "crypto/sha256"
+    "encoding/hex"
+    "time"
+)
+
+// RefreshTokenTTL es la vigencia del refresh token opaco persistido en
+// la tabla refresh_tokens.
+const RefreshTokenTTL = 30 * 24 * time.Hour
+
+const refreshTokenBytes = 32
+
+// GenerateRefreshToken crea un token opaco aleatorio para entregar al
+// cliente junto con su hash SHA-256, que es lo único que se persiste.
+func GenerateRefreshToken() (token string, tokenHash string, err error) {
+    buf := make([]byte, refreshTokenBytes)
+    if _, err := rand.Read(buf); err != nil {
+        return "", "", err
+    }
+    token = hex.EncodeToString(buf)
+    return token, HashToken(token), nil
+}
+
+// HashToken calcula el hash con el que se busca/compara un refresh token
+// en base de datos; el valor en claro nunca se guarda.
+func HashToken(token string) string {
+    sum := sha256.Sum256([]byte(token))
+    return hex.EncodeToString(sum[:])
+}
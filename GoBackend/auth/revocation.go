@@ -0,0 +1,67 @@
+package auth
+
+import (
+    "log/slog"
+    "sync"
+    "time"
+
+    "go-docs-backend/db"
+    "go-docs-backend/models"
+)
+
+var (
+    revokedMu  sync.RWMutex
+    revokedSet = make(map[string]struct{})
+)
+
+// RevokeJTI marca un jti como revocado de inmediato en memoria y lo
+// persiste en revoked_tokens para que otras instancias lo recojan en el
+// siguiente ciclo de StartRevocationSync.
+func RevokeJTI(jti string) error {
+    revokedMu.Lock()
+    revokedSet[jti] = struct{}{}
+    revokedMu.Unlock()
+
+    return db.DB.Create(&models.RevokedToken{JTI: jti, RevokedAt: time.Now()}).Error
+}
+
+func isRevoked(jti string) bool {
+    if jti == "" {
+        return false
+    }
+    revokedMu.RLock()
+    defer revokedMu.RUnlock()
+    _, ok := revokedSet[jti]
+    return ok
+}
+
+// StartRevocationSync arranca una goroutine que refresca periódicamente
+// el set en memoria desde revoked_tokens, de forma que un token robado
+// pueda invalidarse en todas las instancias sin esperar su expiración.
+func StartRevocationSync(interval time.Duration) {
+    syncRevoked()
+    go func() {
+        ticker := time.NewTicker(interval)
+        defer ticker.Stop()
+        for range ticker.C {
+            syncRevoked()
+        }
+    }()
+}
+
+func syncRevoked() {
+    var tokens []models.RevokedToken
+    if err := db.DB.Find(&tokens).Error; err != nil {
+        slog.Error("no se pudo sincronizar tokens revocados", "error", err)
+        return
+    }
+
+    fresh := make(map[string]struct{}, len(tokens))
+    for _, t := range tokens {
+        fresh[t.JTI] = struct{}{}
+    }
+
+    revokedMu.Lock()
+    revokedSet = fresh
+    revokedMu.Unlock()
+}
@@ -0,0 +1,26 @@
+package cache
+
+import (
+    "crypto/sha256"
+    "encoding/hex"
+    "strings"
+)
+
+// HashKey combina parts en una key de caché estable, útil para claves
+// derivadas de una consulta (p. ej. querystring de búsqueda + rol).
+func HashKey(prefix string, parts ...string) string {
+    sum := sha256.Sum256([]byte(strings.Join(parts, "|")))
+    return prefix + ":" + hex.EncodeToString(sum[:])
+}
+
+func DocumentKey(id string) string {
+    return "documents:id:" + id
+}
+
+func DocumentsListKey(rawQuery string) string {
+    return HashKey("documents", rawQuery)
+}
+
+func SearchKey(query, role string) string {
+    return HashKey("search", query, role)
+}
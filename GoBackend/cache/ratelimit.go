@@ -0,0 +1,87 @@
+package cache
+
+import (
+    "context"
+    "fmt"
+    "log/slog"
+    "net"
+    "net/http"
+    "strconv"
+    "time"
+
+    "github.com/redis/go-redis/v9"
+)
+
+// Allow implementa un sliding window log respaldado por un sorted set de
+// Redis: cada solicitud se registra con su timestamp como score, y antes
+// de contar se descartan las entradas anteriores a window. A diferencia
+// de un contador de ventana fija, esto no permite ráfagas de hasta 2x el
+// límite alrededor de un borde de ventana. Si Redis no está disponible,
+// se permite la solicitud (la caché completa se degrada, no solo el rate
+// limiting).
+func Allow(ctx context.Context, key string, limit int, window time.Duration) (allowed bool, retryAfter time.Duration, err error) {
+    if !enabled() {
+        return true, 0, nil
+    }
+
+    now := time.Now()
+    windowStart := now.Add(-window)
+
+    pipe := client.TxPipeline()
+    pipe.ZRemRangeByScore(ctx, key, "0", strconv.FormatInt(windowStart.UnixNano(), 10))
+    pipe.ZAdd(ctx, key, redis.Z{Score: float64(now.UnixNano()), Member: now.UnixNano()})
+    countCmd := pipe.ZCard(ctx, key)
+    pipe.Expire(ctx, key, window)
+    if _, err = pipe.Exec(ctx); err != nil {
+        return true, 0, err
+    }
+
+    if countCmd.Val() <= int64(limit) {
+        return true, 0, nil
+    }
+
+    oldest, err := client.ZRangeWithScores(ctx, key, 0, 0).Result()
+    if err != nil || len(oldest) == 0 {
+        return false, window, nil
+    }
+    retryAfter = window - now.Sub(time.Unix(0, int64(oldest[0].Score)))
+    if retryAfter < 0 {
+        retryAfter = 0
+    }
+    return false, retryAfter, nil
+}
+
+// RateLimitMiddleware limita a limit solicitudes por window para cada
+// key devuelta por keyFunc, respondiendo 429 con Retry-After cuando se
+// excede. keyPrefix separa los contadores de distintos límites (login,
+// api, ...) dentro del mismo namespace de Redis.
+func RateLimitMiddleware(limit int, window time.Duration, keyPrefix string, keyFunc func(*http.Request) string) func(http.Handler) http.Handler {
+    return func(next http.Handler) http.Handler {
+        return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+            key := fmt.Sprintf("ratelimit:%s:%s", keyPrefix, keyFunc(r))
+
+            allowed, retryAfter, err := Allow(r.Context(), key, limit, window)
+            if err != nil {
+                slog.ErrorContext(r.Context(), "cache: error en rate limiting, se permite la solicitud", "error", err)
+            }
+
+            if !allowed {
+                w.Header().Set("Retry-After", strconv.Itoa(int(retryAfter.Seconds())))
+                http.Error(w, "Demasiadas solicitudes", http.StatusTooManyRequests)
+                return
+            }
+
+            next.ServeHTTP(w, r)
+        })
+    }
+}
+
+// KeyByIP extrae la IP del cliente de RemoteAddr, para limitar por IP
+// las rutas que todavía no tienen un usuario autenticado (p. ej. /login).
+func KeyByIP(r *http.Request) string {
+    host, _, err := net.SplitHostPort(r.RemoteAddr)
+    if err != nil {
+        return r.RemoteAddr
+    }
+    return host
+}
@@ -0,0 +1,121 @@
+package cache
+
+import (
+    "context"
+    "encoding/json"
+    "log/slog"
+    "os"
+    "time"
+
+    "github.com/redis/go-redis/v9"
+)
+
+var (
+    client *redis.Client
+    ttl    time.Duration
+)
+
+// Connect intenta conectar con Redis usando REDIS_URL y CACHE_TTL. Si
+// Redis no está configurado o no responde, la caché queda deshabilitada
+// y todas las operaciones se vuelven no-ops: el resto de la API sigue
+// funcionando contra la base de datos directamente.
+func Connect() {
+    url := getEnvOrDefault("REDIS_URL", "redis://localhost:6379/0")
+    ttl = parseDurationOrDefault(getEnvOrDefault("CACHE_TTL", "60s"), time.Minute)
+
+    opts, err := redis.ParseURL(url)
+    if err != nil {
+        slog.Error("cache: REDIS_URL inválida, la caché queda deshabilitada", "error", err)
+        return
+    }
+
+    c := redis.NewClient(opts)
+
+    ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+    defer cancel()
+    if err := c.Ping(ctx).Err(); err != nil {
+        slog.Error("cache: no se pudo conectar a Redis, la caché queda deshabilitada", "error", err)
+        return
+    }
+
+    client = c
+}
+
+func enabled() bool {
+    return client != nil
+}
+
+// Get busca key en la caché y deserializa su valor en dest. Devuelve
+// false tanto en un miss como ante cualquier error (Redis caído,
+// deserialización inválida), para que el llamador siempre pueda
+// resolver el dato desde su fuente original.
+func Get(ctx context.Context, key string, dest interface{}) bool {
+    if !enabled() {
+        return false
+    }
+
+    val, err := client.Get(ctx, key).Result()
+    if err != nil {
+        if err != redis.Nil {
+            slog.ErrorContext(ctx, "cache: error leyendo key", "key", key, "error", err)
+        }
+        return false
+    }
+
+    if err := json.Unmarshal([]byte(val), dest); err != nil {
+        slog.ErrorContext(ctx, "cache: error deserializando key", "key", key, "error", err)
+        return false
+    }
+    return true
+}
+
+// Set guarda value bajo key con el TTL configurado. Los errores solo se
+// registran: una escritura de caché fallida no debe romper la request.
+func Set(ctx context.Context, key string, value interface{}) {
+    if !enabled() {
+        return
+    }
+
+    data, err := json.Marshal(value)
+    if err != nil {
+        slog.ErrorContext(ctx, "cache: error serializando key", "key", key, "error", err)
+        return
+    }
+
+    if err := client.Set(ctx, key, data, ttl).Err(); err != nil {
+        slog.ErrorContext(ctx, "cache: error guardando key", "key", key, "error", err)
+    }
+}
+
+// Invalidate borra todas las keys que hagan match con los patrones dados
+// (sintaxis de glob de Redis, p. ej. "documents:*").
+func Invalidate(ctx context.Context, patterns ...string) {
+    if !enabled() {
+        return
+    }
+
+    for _, pattern := range patterns {
+        iter := client.Scan(ctx, 0, pattern, 0).Iterator()
+        for iter.Next(ctx) {
+            client.Del(ctx, iter.Val())
+        }
+        if err := iter.Err(); err != nil {
+            slog.ErrorContext(ctx, "cache: error invalidando patrón", "pattern", pattern, "error", err)
+        }
+    }
+}
+
+func parseDurationOrDefault(raw string, def time.Duration) time.Duration {
+    d, err := time.ParseDuration(raw)
+    if err != nil {
+        return def
+    }
+    return d
+}
+
+func getEnvOrDefault(key, defaultValue string) string {
+    if value := os.Getenv(key); value != "" {
+        return value
+    }
+    return defaultValue
+}
@@ -4,9 +4,9 @@ import (
     "fmt"
     "gorm.io/driver/postgres"
     "gorm.io/gorm"
-    "log"
-    "time"
+    "log/slog"
     "os"
+    "time"
 )
 
 var DB *gorm.DB
@@ -22,12 +22,14 @@ func Connect() {
     var err error
     DB, err = gorm.Open(postgres.Open(dsn), &gorm.Config{})
     if err != nil {
-        log.Fatal("Error al conectar con la base de datos:", err)
+        slog.Error("error al conectar con la base de datos", "error", err)
+        os.Exit(1)
     }
 
 	sqlDB, err := DB.DB()
 	if err != nil {
-		log.Fatal("Error al obtener la conexión SQL:", err)
+		slog.Error("error al obtener la conexión SQL", "error", err)
+		os.Exit(1)
 	}
     // Agregamos configuracion para pooling en la conexion para 
     // preparar alto trafico
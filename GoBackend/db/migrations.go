@@ -0,0 +1,48 @@
+package db
+
+import "fmt"
+
+var supportedSearchLanguages = map[string]bool{
+    "simple":  true,
+    "english": true,
+    "spanish": true,
+}
+
+// ValidSearchLanguage devuelve lang si es un config de texto soportado,
+// o "simple" en caso contrario (config neutro de Postgres que no aplica
+// stemming ni stopwords de ningún idioma).
+func ValidSearchLanguage(lang string) string {
+    if supportedSearchLanguages[lang] {
+        return lang
+    }
+    return "simple"
+}
+
+// SearchLanguage es el idioma por defecto usado para indexar y buscar,
+// configurable vía la variable de entorno SEARCH_LANG.
+func SearchLanguage() string {
+    return ValidSearchLanguage(getEnvOrDefault("SEARCH_LANG", "simple"))
+}
+
+// MigrateFullTextSearch agrega la columna generada search_vector (con
+// pesos A/B/C/D para title/subtitle/keywords/content) y su índice GIN
+// sobre documents. GORM AutoMigrate no soporta columnas generadas, así
+// que esto se ejecuta como SQL crudo e idempotente.
+func MigrateFullTextSearch() error {
+    lang := SearchLanguage()
+
+    ddl := fmt.Sprintf(`
+        ALTER TABLE documents ADD COLUMN IF NOT EXISTS search_vector tsvector
+            GENERATED ALWAYS AS (
+                setweight(to_tsvector('%s', coalesce(title, '')), 'A') ||
+                setweight(to_tsvector('%s', coalesce(subtitle, '')), 'B') ||
+                setweight(to_tsvector('%s', array_to_string(keywords, ' ')), 'C') ||
+                setweight(to_tsvector('%s', coalesce(content, '')), 'D')
+            ) STORED`, lang, lang, lang, lang)
+
+    if err := DB.Exec(ddl).Error; err != nil {
+        return err
+    }
+
+    return DB.Exec(`CREATE INDEX IF NOT EXISTS documents_search_vector_idx ON documents USING GIN (search_vector)`).Error
+}
@@ -0,0 +1,41 @@
+package handlers
+
+import (
+    "encoding/json"
+    "net/http"
+    "time"
+
+    "go-docs-backend/db"
+    "go-docs-backend/models"
+)
+
+// GetAuditLog lista el registro de auditoría de documentos, restringido
+// a administradores: GET /audit?document_id=&user_id=&from=&to=
+func GetAuditLog(w http.ResponseWriter, r *http.Request) {
+    params := r.URL.Query()
+    query := db.DB.Model(&models.DocumentAuditLog{})
+
+    if v := params.Get("document_id"); v != "" {
+        query = query.Where("document_id = ?", v)
+    }
+    if v := params.Get("user_id"); v != "" {
+        query = query.Where("user_id = ?", v)
+    }
+    if v := params.Get("from"); v != "" {
+        if t, err := time.Parse(time.RFC3339, v); err == nil {
+            query = query.Where("created_at >= ?", t)
+        }
+    }
+    if v := params.Get("to"); v != "" {
+        if t, err := time.Parse(time.RFC3339, v); err == nil {
+            query = query.Where("created_at <= ?", t)
+        }
+    }
+
+    var logs []models.DocumentAuditLog
+    if err := query.Order("created_at desc").Find(&logs).Error; err != nil {
+        http.Error(w, "Error al obtener el registro de auditoría", http.StatusInternalServerError)
+        return
+    }
+    json.NewEncoder(w).Encode(logs)
+}
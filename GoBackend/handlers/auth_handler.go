@@ -0,0 +1,101 @@
+package handlers
+
+import (
+    "encoding/json"
+    "net/http"
+    "time"
+
+    "go-docs-backend/auth"
+    "go-docs-backend/db"
+    "go-docs-backend/models"
+)
+
+type tokenPair struct {
+    AccessToken  string `json:"access_token"`
+    RefreshToken string `json:"refresh_token"`
+}
+
+// issueTokenPair genera un access token y un refresh token para el
+// usuario, persistiendo este último en refresh_tokens.
+func issueTokenPair(user models.User, r *http.Request) (tokenPair, error) {
+    accessToken, err := auth.GenerateJWT(user.ID, string(user.Role))
+    if err != nil {
+        return tokenPair{}, err
+    }
+
+    refreshToken, refreshHash, err := auth.GenerateRefreshToken()
+    if err != nil {
+        return tokenPair{}, err
+    }
+
+    record := models.RefreshToken{
+        UserID:    user.ID,
+        TokenHash: refreshHash,
+        ExpiresAt: time.Now().Add(auth.RefreshTokenTTL),
+        UserAgent: r.UserAgent(),
+        IP:        r.RemoteAddr,
+    }
+    if err := db.DB.Create(&record).Error; err != nil {
+        return tokenPair{}, err
+    }
+
+    return tokenPair{AccessToken: accessToken, RefreshToken: refreshToken}, nil
+}
+
+func RefreshHandler(w http.ResponseWriter, r *http.Request) {
+    var input struct {
+        RefreshToken string `json:"refresh_token"`
+    }
+    if err := json.NewDecoder(r.Body).Decode(&input); err != nil || input.RefreshToken == "" {
+        http.Error(w, "Datos inválidos", http.StatusBadRequest)
+        return
+    }
+
+    var stored models.RefreshToken
+    if err := db.DB.Where("token_hash = ?", auth.HashToken(input.RefreshToken)).First(&stored).Error; err != nil {
+        http.Error(w, "Refresh token inválido", http.StatusUnauthorized)
+        return
+    }
+
+    if stored.RevokedAt != nil || time.Now().After(stored.ExpiresAt) {
+        http.Error(w, "Refresh token inválido", http.StatusUnauthorized)
+        return
+    }
+
+    var user models.User
+    if err := db.DB.First(&user, stored.UserID).Error; err != nil {
+        http.Error(w, "Usuario no encontrado", http.StatusUnauthorized)
+        return
+    }
+
+    // Rotación: el refresh token usado queda revocado aunque el nuevo falle.
+    db.DB.Model(&stored).Update("revoked_at", time.Now())
+
+    pair, err := issueTokenPair(user, r)
+    if err != nil {
+        http.Error(w, "Error generando tokens", http.StatusInternalServerError)
+        return
+    }
+
+    json.NewEncoder(w).Encode(pair)
+}
+
+func LogoutHandler(w http.ResponseWriter, r *http.Request) {
+    var input struct {
+        RefreshToken string `json:"refresh_token"`
+    }
+    if err := json.NewDecoder(r.Body).Decode(&input); err != nil || input.RefreshToken == "" {
+        http.Error(w, "Datos inválidos", http.StatusBadRequest)
+        return
+    }
+
+    db.DB.Model(&models.RefreshToken{}).
+        Where("token_hash = ? AND revoked_at IS NULL", auth.HashToken(input.RefreshToken)).
+        Update("revoked_at", time.Now())
+
+    if claims, ok := auth.ClaimsFromContext(r.Context()); ok {
+        auth.RevokeJTI(claims.ID)
+    }
+
+    w.WriteHeader(http.StatusNoContent)
+}
@@ -1,15 +1,25 @@
 package handlers
 
 import (
+    "context"
     "encoding/json"
     "net/http"
-    "strconv"
 
     "github.com/gorilla/mux"
+    "go-docs-backend/audit"
+    "go-docs-backend/auth"
+    "go-docs-backend/cache"
     "go-docs-backend/db"
     "go-docs-backend/models"
 )
 
+// invalidateDocumentCache borra tanto el listado/detalle de documentos
+// como los resultados de búsqueda cacheados, que pueden incluir campos
+// de cualquier documento.
+func invalidateDocumentCache(ctx context.Context) {
+    cache.Invalidate(ctx, "documents:*", "search:*")
+}
+
 func CreateDocument(w http.ResponseWriter, r *http.Request) {
     var doc models.Document
     if err := json.NewDecoder(r.Body).Decode(&doc); err != nil {
@@ -17,31 +27,78 @@ func CreateDocument(w http.ResponseWriter, r *http.Request) {
         return
     }
 
+    if claims, ok := auth.ClaimsFromContext(r.Context()); ok {
+        doc.UserID = claims.UserID
+    }
+
     if result := db.DB.Create(&doc); result.Error != nil {
         http.Error(w, "Error al guardar", http.StatusInternalServerError)
         return
     }
 
+    invalidateDocumentCache(r.Context())
+    audit.Record(r, doc.UserID, audit.ActionCreate, doc.ID, nil, doc)
+
     w.WriteHeader(http.StatusCreated)
     json.NewEncoder(w).Encode(doc)
 }
 
 func GetAllDocuments(w http.ResponseWriter, r *http.Request) {
+    key := cache.DocumentsListKey(r.URL.RawQuery)
+    var envelope listEnvelope
+    if cache.Get(r.Context(), key, &envelope) {
+        setPaginationLinkHeader(w, r, envelope.Page, envelope.TotalPages)
+        json.NewEncoder(w).Encode(envelope)
+        return
+    }
+
+    page := parsePage(r.URL.Query().Get("page"))
+    pageSize := parsePageSize(r.URL.Query().Get("page_size"))
+
+    filtered := applyDocumentFilters(db.DB.Model(&models.Document{}), r)
+
+    var total int64
+    if err := filtered.Count(&total).Error; err != nil {
+        http.Error(w, "Error al obtener documentos", http.StatusInternalServerError)
+        return
+    }
+
     var docs []models.Document
-    if err := db.DB.Find(&docs).Error; err != nil {
+    query := applyDocumentSort(filtered, r.URL.Query().Get("sort"))
+    if err := query.Offset((page - 1) * pageSize).Limit(pageSize).Find(&docs).Error; err != nil {
         http.Error(w, "Error al obtener documentos", http.StatusInternalServerError)
         return
     }
-    json.NewEncoder(w).Encode(docs)
+
+    envelope = listEnvelope{
+        Data:       docs,
+        Page:       page,
+        PageSize:   pageSize,
+        Total:      total,
+        TotalPages: totalPages(total, pageSize),
+    }
+
+    cache.Set(r.Context(), key, envelope)
+    setPaginationLinkHeader(w, r, envelope.Page, envelope.TotalPages)
+    json.NewEncoder(w).Encode(envelope)
 }
 
 func GetDocumentByID(w http.ResponseWriter, r *http.Request) {
     id := mux.Vars(r)["id"]
+
     var doc models.Document
+    key := cache.DocumentKey(id)
+    if cache.Get(r.Context(), key, &doc) {
+        json.NewEncoder(w).Encode(doc)
+        return
+    }
+
     if err := db.DB.First(&doc, id).Error; err != nil {
         http.Error(w, "Documento no encontrado", http.StatusNotFound)
         return
     }
+
+    cache.Set(r.Context(), key, doc)
     json.NewEncoder(w).Encode(doc)
 }
 
@@ -53,22 +110,58 @@ func UpdateDocument(w http.ResponseWriter, r *http.Request) {
         return
     }
 
+    if claims, ok := auth.ClaimsFromContext(r.Context()); ok {
+        if claims.Role != string(models.RoleAdmin) && claims.UserID != doc.UserID {
+            http.Error(w, "No puede modificar documentos de otro usuario", http.StatusForbidden)
+            return
+        }
+    }
+
     var updated models.Document
     if err := json.NewDecoder(r.Body).Decode(&updated); err != nil {
         http.Error(w, "Datos inválidos", http.StatusBadRequest)
         return
     }
+    // El ID y el dueño del documento no son modificables vía este endpoint.
+    updated.ID = doc.ID
+    updated.UserID = doc.UserID
 
+    before := doc
     db.DB.Model(&doc).Updates(updated)
+    invalidateDocumentCache(r.Context())
+    audit.Record(r, actorUserID(r), audit.ActionUpdate, doc.ID, before, doc)
     json.NewEncoder(w).Encode(doc)
 }
 
 func DeleteDocument(w http.ResponseWriter, r *http.Request) {
     id := mux.Vars(r)["id"]
     var doc models.Document
+    if err := db.DB.First(&doc, id).Error; err != nil {
+        http.Error(w, "Documento no encontrado", http.StatusNotFound)
+        return
+    }
+
+    if claims, ok := auth.ClaimsFromContext(r.Context()); ok {
+        if claims.Role != string(models.RoleAdmin) && claims.UserID != doc.UserID {
+            http.Error(w, "No puede eliminar documentos de otro usuario", http.StatusForbidden)
+            return
+        }
+    }
+
     if err := db.DB.Delete(&doc, id).Error; err != nil {
         http.Error(w, "Error al eliminar", http.StatusInternalServerError)
         return
     }
+    invalidateDocumentCache(r.Context())
+    audit.Record(r, actorUserID(r), audit.ActionDelete, doc.ID, doc, nil)
     w.WriteHeader(http.StatusNoContent)
 }
+
+// actorUserID devuelve el user_id de las claims del request, o 0 si no
+// hay un usuario autenticado (no debería ocurrir en rutas protegidas).
+func actorUserID(r *http.Request) uint {
+    if claims, ok := auth.ClaimsFromContext(r.Context()); ok {
+        return claims.UserID
+    }
+    return 0
+}
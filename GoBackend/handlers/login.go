@@ -3,8 +3,11 @@ package handlers
 import (
     "encoding/json"
     "net/http"
-    "os"
-    "go-docs-backend/auth"
+
+    "golang.org/x/crypto/bcrypt"
+
+    "go-docs-backend/db"
+    "go-docs-backend/models"
 )
 
 func LoginHandler(w http.ResponseWriter, r *http.Request) {
@@ -18,27 +21,22 @@ func LoginHandler(w http.ResponseWriter, r *http.Request) {
         return
     }
 
-    // Validación usando variables de entorno
-    adminUser := getEnvOrDefault("ADMIN_USER", "admin")
-    adminPass := getEnvOrDefault("ADMIN_PASS", "1234")
-    
-    if credentials.Username != adminUser || credentials.Password != adminPass {
+    var user models.User
+    if err := db.DB.Where("username = ?", credentials.Username).First(&user).Error; err != nil {
         http.Error(w, "Credenciales inválidas", http.StatusUnauthorized)
         return
     }
 
-    token, err := auth.GenerateJWT(1)
-    if err != nil {
-        http.Error(w, "Error generando token", http.StatusInternalServerError)
+    if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(credentials.Password)); err != nil {
+        http.Error(w, "Credenciales inválidas", http.StatusUnauthorized)
         return
     }
 
-    json.NewEncoder(w).Encode(map[string]string{"token": token})
-}
-
-func getEnvOrDefault(key, defaultValue string) string {
-    if value := os.Getenv(key); value != "" {
-        return value
+    pair, err := issueTokenPair(user, r)
+    if err != nil {
+        http.Error(w, "Error generando tokens", http.StatusInternalServerError)
+        return
     }
-    return defaultValue
+
+    json.NewEncoder(w).Encode(pair)
 }
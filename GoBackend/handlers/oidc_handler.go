@@ -0,0 +1,112 @@
+package handlers
+
+import (
+    "encoding/json"
+    "net/http"
+
+    "github.com/gorilla/mux"
+
+    "go-docs-backend/db"
+    "go-docs-backend/models"
+    "go-docs-backend/oidc"
+)
+
+// OIDCLoginHandler redirige al usuario hacia el proveedor OIDC indicado
+// en {provider}, iniciando el flujo de autorización con PKCE.
+func OIDCLoginHandler(w http.ResponseWriter, r *http.Request) {
+    providerName := mux.Vars(r)["provider"]
+    provider, ok := oidc.Get(providerName)
+    if !ok {
+        http.Error(w, "Proveedor no soportado", http.StatusNotFound)
+        return
+    }
+
+    authURL, err := oidc.StartFlow(w, provider)
+    if err != nil {
+        http.Error(w, "Error iniciando el login", http.StatusInternalServerError)
+        return
+    }
+
+    http.Redirect(w, r, authURL, http.StatusFound)
+}
+
+// OIDCCallbackHandler recibe el callback del proveedor, intercambia el
+// código por tokens, verifica el ID token y emite el mismo formato de
+// JWT que LoginHandler para el User vinculado o recién aprovisionado.
+func OIDCCallbackHandler(w http.ResponseWriter, r *http.Request) {
+    providerName := mux.Vars(r)["provider"]
+    provider, ok := oidc.Get(providerName)
+    if !ok {
+        http.Error(w, "Proveedor no soportado", http.StatusNotFound)
+        return
+    }
+
+    query := r.URL.Query()
+    if msg := query.Get("error"); msg != "" {
+        http.Error(w, "Login cancelado: "+msg, http.StatusBadRequest)
+        return
+    }
+
+    code := query.Get("code")
+    state := query.Get("state")
+    if code == "" || state == "" {
+        http.Error(w, "Respuesta de callback inválida", http.StatusBadRequest)
+        return
+    }
+
+    codeVerifier, err := oidc.CompleteFlow(r, providerName, state)
+    if err != nil {
+        http.Error(w, "Estado de login inválido o expirado", http.StatusBadRequest)
+        return
+    }
+
+    token, err := provider.Exchange(r.Context(), code, codeVerifier)
+    if err != nil {
+        http.Error(w, "Error intercambiando el código de autorización", http.StatusUnauthorized)
+        return
+    }
+
+    claims, err := provider.VerifyIDToken(r.Context(), token)
+    if err != nil {
+        http.Error(w, "ID token inválido", http.StatusUnauthorized)
+        return
+    }
+    if !claims.EmailVerified || claims.Email == "" {
+        http.Error(w, "El proveedor no reportó un email verificado", http.StatusUnauthorized)
+        return
+    }
+
+    user, err := findOrProvisionOIDCUser(claims)
+    if err != nil {
+        http.Error(w, "Error vinculando la cuenta", http.StatusInternalServerError)
+        return
+    }
+
+    pair, err := issueTokenPair(user, r)
+    if err != nil {
+        http.Error(w, "Error generando tokens", http.StatusInternalServerError)
+        return
+    }
+
+    json.NewEncoder(w).Encode(pair)
+}
+
+// findOrProvisionOIDCUser vincula el login social a un User existente
+// por email verificado, o aprovisiona uno nuevo con rol reader si no
+// hay ninguno todavía.
+func findOrProvisionOIDCUser(claims *oidc.Claims) (models.User, error) {
+    var user models.User
+    if err := db.DB.Where("email = ?", claims.Email).First(&user).Error; err == nil {
+        return user, nil
+    }
+
+    user = models.User{
+        Username: claims.Email,
+        Email:    claims.Email,
+        Role:     models.RoleReader,
+    }
+    if err := db.DB.Create(&user).Error; err != nil {
+        return models.User{}, err
+    }
+    return user, nil
+}
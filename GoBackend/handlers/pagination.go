@@ -0,0 +1,131 @@
+package handlers
+
+import (
+    "fmt"
+    "net/http"
+    "strconv"
+    "strings"
+    "time"
+
+    "gorm.io/gorm"
+)
+
+const (
+    defaultPageSize = 20
+    maxPageSize     = 100
+)
+
+// listEnvelope es el formato estándar de respuesta para endpoints de
+// listado paginados: {"data": [...], "page": 1, "page_size": 20, ...}.
+type listEnvelope struct {
+    Data       interface{} `json:"data"`
+    Page       int         `json:"page"`
+    PageSize   int         `json:"page_size"`
+    Total      int64       `json:"total"`
+    TotalPages int         `json:"total_pages"`
+}
+
+func parsePage(raw string) int {
+    return parseIntParam(raw, 1, 1, 0)
+}
+
+func parsePageSize(raw string) int {
+    return parseIntParam(raw, defaultPageSize, 1, maxPageSize)
+}
+
+func totalPages(total int64, pageSize int) int {
+    if pageSize <= 0 || total <= 0 {
+        return 0
+    }
+    pages := int(total) / pageSize
+    if int(total)%pageSize != 0 {
+        pages++
+    }
+    return pages
+}
+
+// setPaginationLinkHeader agrega relaciones rel="next"/rel="prev" al
+// header Link, apuntando a la misma URL con el parámetro page ajustado.
+func setPaginationLinkHeader(w http.ResponseWriter, r *http.Request, page, pages int) {
+    var links []string
+    if page > 1 {
+        links = append(links, fmt.Sprintf(`<%s>; rel="prev"`, pageURL(r, page-1)))
+    }
+    if pages > 0 && page < pages {
+        links = append(links, fmt.Sprintf(`<%s>; rel="next"`, pageURL(r, page+1)))
+    }
+    if len(links) > 0 {
+        w.Header().Set("Link", strings.Join(links, ", "))
+    }
+}
+
+func pageURL(r *http.Request, page int) string {
+    q := r.URL.Query()
+    q.Set("page", strconv.Itoa(page))
+    u := *r.URL
+    u.RawQuery = q.Encode()
+    return u.String()
+}
+
+// applyDocumentFilters aplica los filtros comunes de los endpoints de
+// listado y búsqueda de documentos (created_after/created_before,
+// keyword, title_contains) a una query de GORM.
+func applyDocumentFilters(q *gorm.DB, r *http.Request) *gorm.DB {
+    params := r.URL.Query()
+
+    if v := params.Get("created_after"); v != "" {
+        if t, err := time.Parse(time.RFC3339, v); err == nil {
+            q = q.Where("created_at >= ?", t)
+        }
+    }
+    if v := params.Get("created_before"); v != "" {
+        if t, err := time.Parse(time.RFC3339, v); err == nil {
+            q = q.Where("created_at <= ?", t)
+        }
+    }
+    if v := params.Get("keyword"); v != "" {
+        q = q.Where("? = ANY (keywords)", v)
+    }
+    if v := params.Get("title_contains"); v != "" {
+        q = q.Where("LOWER(title) LIKE LOWER(?)", "%"+v+"%")
+    }
+
+    return q
+}
+
+var documentSortColumns = map[string]bool{
+    "id":         true,
+    "created_at": true,
+    "title":      true,
+}
+
+const defaultDocumentSort = "created_at.desc"
+
+// applyDocumentSort aplica `sort=columna.dirección` (p. ej.
+// created_at.desc) a una query, restringido a documentSortColumns para
+// evitar inyectar columnas arbitrarias.
+func applyDocumentSort(q *gorm.DB, raw string) *gorm.DB {
+    col, dir := parseSort(raw)
+    return q.Order(fmt.Sprintf("%s %s", col, dir))
+}
+
+func parseSort(raw string) (col, dir string) {
+    if raw == "" {
+        raw = defaultDocumentSort
+    }
+
+    parts := strings.SplitN(raw, ".", 2)
+    col = parts[0]
+    dir = "asc"
+    if len(parts) == 2 {
+        dir = strings.ToLower(parts[1])
+    }
+
+    if !documentSortColumns[col] {
+        return parseSort(defaultDocumentSort)
+    }
+    if dir != "asc" && dir != "desc" {
+        dir = "asc"
+    }
+    return col, dir
+}
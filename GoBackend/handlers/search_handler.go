@@ -1,54 +1,237 @@
 package handlers
 
 import (
+    "encoding/json"
+    "fmt"
     "net/http"
+    "strconv"
     "strings"
+    "time"
+
+    "go-docs-backend/auth"
+    "go-docs-backend/cache"
     "go-docs-backend/db"
     "go-docs-backend/models"
-    "encoding/json"
 )
 
-func SearchByKeyword(w http.ResponseWriter, r *http.Request) {
-    term := strings.TrimSpace(r.URL.Query().Get("term"))
-    if term == "" {
-        http.Error(w, "Término de búsqueda requerido", http.StatusBadRequest)
+var searchFieldWeights = map[string]string{
+    "title":    "A",
+    "subtitle": "B",
+    "keywords": "C",
+    "content":  "D",
+}
+
+var defaultSearchFields = []string{"title", "subtitle", "keywords", "content"}
+
+type searchResult struct {
+    models.Document
+    Rank     float64 `json:"rank"`
+    Headline string  `json:"headline"`
+}
+
+// Search unifica las antiguas búsquedas por keyword/title/subtitle en un
+// único endpoint de texto completo sobre Postgres:
+// GET /search?q=...&fields=title,content&lang=spanish&page=1&page_size=20
+// y acepta los mismos filtros que GetAllDocuments (created_after,
+// created_before, keyword, title_contains), devolviendo el mismo
+// envelope paginado {data, page, page_size, total, total_pages}.
+func Search(w http.ResponseWriter, r *http.Request) {
+    q := strings.TrimSpace(r.URL.Query().Get("q"))
+    if q == "" {
+        http.Error(w, "Parámetro de búsqueda 'q' requerido", http.StatusBadRequest)
         return
     }
-    
-    var docs []models.Document
-    if err := db.DB.Where("? = ANY (keywords)", term).Find(&docs).Error; err != nil {
-        http.Error(w, "Error en la búsqueda", http.StatusInternalServerError)
+
+    lang := r.URL.Query().Get("lang")
+    if lang == "" {
+        lang = db.SearchLanguage()
+    }
+    lang = db.ValidSearchLanguage(lang)
+
+    fields := parseSearchFields(r.URL.Query().Get("fields"))
+    page := parsePage(r.URL.Query().Get("page"))
+    pageSize := parsePageSize(r.URL.Query().Get("page_size"))
+
+    role := "anon"
+    if claims, ok := auth.ClaimsFromContext(r.Context()); ok {
+        role = claims.Role
+    }
+
+    cacheKey := cache.SearchKey(r.URL.RawQuery, role)
+    var envelope listEnvelope
+    if cache.Get(r.Context(), cacheKey, &envelope) {
+        setPaginationLinkHeader(w, r, envelope.Page, envelope.TotalPages)
+        json.NewEncoder(w).Encode(envelope)
         return
     }
-    json.NewEncoder(w).Encode(docs)
-}
 
-func SearchByTitle(w http.ResponseWriter, r *http.Request) {
-    term := strings.TrimSpace(r.URL.Query().Get("term"))
-    if term == "" {
-        http.Error(w, "Término de búsqueda requerido", http.StatusBadRequest)
+    queryExpr, queryArg := buildTSQueryExpr(q)
+
+    // search_vector es una columna generada en el idioma fijado en
+    // SEARCH_LANG al momento de migrar (ver db.MigrateFullTextSearch), así
+    // que solo puede reutilizarse cuando el lang pedido coincide con ese
+    // idioma; de lo contrario hay que reconstruir el tsvector al vuelo
+    // para que sus lexemas casen con los de la tsquery.
+    vectorExpr := "search_vector"
+    if !sameSearchFields(fields, defaultSearchFields) || lang != db.SearchLanguage() {
+        vectorExpr = buildVectorExpr(lang, fields)
+    }
+
+    filterConds, filterArgs := searchFilterConditions(r)
+    whereSQL := strings.Join(append([]string{fmt.Sprintf("%s @@ query", vectorExpr)}, filterConds...), " AND ")
+
+    countSQL := fmt.Sprintf("SELECT count(*) FROM documents, %s query WHERE %s", queryExpr, whereSQL)
+    countArgs := append([]interface{}{lang, queryArg}, filterArgs...)
+
+    var total int64
+    if err := db.DB.Raw(countSQL, countArgs...).Scan(&total).Error; err != nil {
+        http.Error(w, "Error en la búsqueda", http.StatusInternalServerError)
         return
     }
-    
-    var docs []models.Document
-    if err := db.DB.Where("LOWER(title) LIKE LOWER(?)", "%"+term+"%").Find(&docs).Error; err != nil {
+
+    dataSQL := fmt.Sprintf(`
+        SELECT documents.*,
+               ts_rank_cd(%s, query) AS rank,
+               ts_headline(?, content, query, 'StartSel=<mark>,StopSel=</mark>') AS headline
+        FROM documents, %s query
+        WHERE %s
+        ORDER BY rank DESC
+        LIMIT ? OFFSET ?`, vectorExpr, queryExpr, whereSQL)
+
+    dataArgs := append([]interface{}{lang, lang, queryArg}, filterArgs...)
+    dataArgs = append(dataArgs, pageSize, (page-1)*pageSize)
+
+    var results []searchResult
+    if err := db.DB.Raw(dataSQL, dataArgs...).Scan(&results).Error; err != nil {
         http.Error(w, "Error en la búsqueda", http.StatusInternalServerError)
         return
     }
-    json.NewEncoder(w).Encode(docs)
+
+    envelope = listEnvelope{
+        Data:       results,
+        Page:       page,
+        PageSize:   pageSize,
+        Total:      total,
+        TotalPages: totalPages(total, pageSize),
+    }
+
+    cache.Set(r.Context(), cacheKey, envelope)
+    setPaginationLinkHeader(w, r, envelope.Page, envelope.TotalPages)
+    json.NewEncoder(w).Encode(envelope)
 }
 
-func SearchBySubtitle(w http.ResponseWriter, r *http.Request) {
-    term := strings.TrimSpace(r.URL.Query().Get("term"))
-    if term == "" {
-        http.Error(w, "Término de búsqueda requerido", http.StatusBadRequest)
-        return
+// searchFilterConditions traduce los mismos filtros que
+// applyDocumentFilters a condiciones SQL parametrizadas, para usarlas
+// dentro del SQL crudo de búsqueda de texto completo.
+func searchFilterConditions(r *http.Request) (conditions []string, args []interface{}) {
+    params := r.URL.Query()
+
+    if v := params.Get("created_after"); v != "" {
+        if t, err := time.Parse(time.RFC3339, v); err == nil {
+            conditions = append(conditions, "documents.created_at >= ?")
+            args = append(args, t)
+        }
     }
-    
-    var docs []models.Document
-    if err := db.DB.Where("LOWER(subtitle) LIKE LOWER(?)", "%"+term+"%").Find(&docs).Error; err != nil {
-        http.Error(w, "Error en la búsqueda", http.StatusInternalServerError)
-        return
+    if v := params.Get("created_before"); v != "" {
+        if t, err := time.Parse(time.RFC3339, v); err == nil {
+            conditions = append(conditions, "documents.created_at <= ?")
+            args = append(args, t)
+        }
+    }
+    if v := params.Get("keyword"); v != "" {
+        conditions = append(conditions, "? = ANY (documents.keywords)")
+        args = append(args, v)
+    }
+    if v := params.Get("title_contains"); v != "" {
+        conditions = append(conditions, "LOWER(documents.title) LIKE LOWER(?)")
+        args = append(args, "%"+v+"%")
+    }
+
+    return conditions, args
+}
+
+func parseSearchFields(raw string) []string {
+    if raw == "" {
+        return defaultSearchFields
+    }
+
+    var fields []string
+    for _, f := range strings.Split(raw, ",") {
+        f = strings.TrimSpace(f)
+        if _, ok := searchFieldWeights[f]; ok {
+            fields = append(fields, f)
+        }
+    }
+    if len(fields) == 0 {
+        return defaultSearchFields
+    }
+    return fields
+}
+
+func sameSearchFields(fields, defaults []string) bool {
+    if len(fields) != len(defaults) {
+        return false
+    }
+    for i, f := range fields {
+        if f != defaults[i] {
+            return false
+        }
+    }
+    return true
+}
+
+// buildVectorExpr arma el tsvector sobre la marcha para un subconjunto de
+// columnas. lang y los nombres de columna solo provienen de valores ya
+// validados contra listas fijas, por lo que es seguro interpolarlos.
+func buildVectorExpr(lang string, fields []string) string {
+    parts := make([]string, 0, len(fields))
+    for _, f := range fields {
+        col := fmt.Sprintf("coalesce(%s, '')", f)
+        if f == "keywords" {
+            col = "array_to_string(keywords, ' ')"
+        }
+        parts = append(parts, fmt.Sprintf("setweight(to_tsvector('%s', %s), '%s')", lang, col, searchFieldWeights[f]))
+    }
+    return strings.Join(parts, " || ")
+}
+
+// buildTSQueryExpr interpreta la sintaxis de búsqueda del usuario y
+// devuelve la llamada SQL a usar (siempre con placeholders `?` para lang
+// y el término) junto con el argumento que corresponde al término:
+//   - "frase entre comillas"  -> phraseto_tsquery
+//   - término*                -> prefijo (:*) vía to_tsquery
+//   - cualquier otra cosa     -> plainto_tsquery
+func buildTSQueryExpr(q string) (sqlExpr string, arg string) {
+    if strings.HasPrefix(q, `"`) && strings.HasSuffix(q, `"`) && len(q) > 1 {
+        return "phraseto_tsquery(?, ?)", strings.Trim(q, `"`)
+    }
+    if strings.HasSuffix(q, "*") {
+        return "to_tsquery(?, ?)", toPrefixQuery(strings.TrimSuffix(q, "*"))
+    }
+    return "plainto_tsquery(?, ?)", q
+}
+
+func toPrefixQuery(term string) string {
+    words := strings.Fields(term)
+    for i, word := range words {
+        words[i] = word + ":*"
+    }
+    return strings.Join(words, " & ")
+}
+
+func parseIntParam(raw string, def, min, max int) int {
+    if raw == "" {
+        return def
+    }
+    value, err := strconv.Atoi(raw)
+    if err != nil {
+        return def
+    }
+    if value < min {
+        return min
+    }
+    if max > 0 && value > max {
+        return max
     }
-    json.NewEncoder(w).Encode(docs)
+    return value
 }
@@ -0,0 +1,155 @@
+package handlers
+
+import (
+    "encoding/json"
+    "net/http"
+
+    "github.com/gorilla/mux"
+    "golang.org/x/crypto/bcrypt"
+
+    "go-docs-backend/db"
+    "go-docs-backend/models"
+)
+
+const bcryptCost = 12
+
+// RegisterHandler crea una cuenta nueva con rol "reader". Para otorgar
+// roles superiores hay que usar CreateUser (requiere admin).
+func RegisterHandler(w http.ResponseWriter, r *http.Request) {
+    var input struct {
+        Username string `json:"username"`
+        Email    string `json:"email"`
+        Password string `json:"password"`
+    }
+
+    if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+        http.Error(w, "Datos inválidos", http.StatusBadRequest)
+        return
+    }
+
+    if input.Username == "" || input.Email == "" || input.Password == "" {
+        http.Error(w, "Username, email y password son requeridos", http.StatusBadRequest)
+        return
+    }
+
+    hash, err := bcrypt.GenerateFromPassword([]byte(input.Password), bcryptCost)
+    if err != nil {
+        http.Error(w, "Error generando credenciales", http.StatusInternalServerError)
+        return
+    }
+
+    user := models.User{
+        Username:     input.Username,
+        Email:        input.Email,
+        PasswordHash: string(hash),
+        Role:         models.RoleReader,
+    }
+
+    if err := db.DB.Create(&user).Error; err != nil {
+        http.Error(w, "Error al registrar usuario", http.StatusInternalServerError)
+        return
+    }
+
+    w.WriteHeader(http.StatusCreated)
+    json.NewEncoder(w).Encode(user)
+}
+
+// CreateUser permite a un admin dar de alta usuarios con cualquier rol.
+func CreateUser(w http.ResponseWriter, r *http.Request) {
+    var input struct {
+        Username string      `json:"username"`
+        Email    string      `json:"email"`
+        Password string      `json:"password"`
+        Role     models.Role `json:"role"`
+    }
+
+    if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+        http.Error(w, "Datos inválidos", http.StatusBadRequest)
+        return
+    }
+
+    if input.Role == "" {
+        input.Role = models.RoleReader
+    }
+
+    hash, err := bcrypt.GenerateFromPassword([]byte(input.Password), bcryptCost)
+    if err != nil {
+        http.Error(w, "Error generando credenciales", http.StatusInternalServerError)
+        return
+    }
+
+    user := models.User{
+        Username:     input.Username,
+        Email:        input.Email,
+        PasswordHash: string(hash),
+        Role:         input.Role,
+    }
+
+    if err := db.DB.Create(&user).Error; err != nil {
+        http.Error(w, "Error al crear usuario", http.StatusInternalServerError)
+        return
+    }
+
+    w.WriteHeader(http.StatusCreated)
+    json.NewEncoder(w).Encode(user)
+}
+
+func GetAllUsers(w http.ResponseWriter, r *http.Request) {
+    var users []models.User
+    if err := db.DB.Find(&users).Error; err != nil {
+        http.Error(w, "Error al obtener usuarios", http.StatusInternalServerError)
+        return
+    }
+    json.NewEncoder(w).Encode(users)
+}
+
+func UpdateUser(w http.ResponseWriter, r *http.Request) {
+    id := mux.Vars(r)["id"]
+    var user models.User
+    if err := db.DB.First(&user, id).Error; err != nil {
+        http.Error(w, "Usuario no encontrado", http.StatusNotFound)
+        return
+    }
+
+    var input struct {
+        Username string      `json:"username"`
+        Email    string      `json:"email"`
+        Password string      `json:"password"`
+        Role     models.Role `json:"role"`
+    }
+    if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+        http.Error(w, "Datos inválidos", http.StatusBadRequest)
+        return
+    }
+
+    updates := map[string]interface{}{}
+    if input.Username != "" {
+        updates["username"] = input.Username
+    }
+    if input.Email != "" {
+        updates["email"] = input.Email
+    }
+    if input.Role != "" {
+        updates["role"] = input.Role
+    }
+    if input.Password != "" {
+        hash, err := bcrypt.GenerateFromPassword([]byte(input.Password), bcryptCost)
+        if err != nil {
+            http.Error(w, "Error generando credenciales", http.StatusInternalServerError)
+            return
+        }
+        updates["password_hash"] = string(hash)
+    }
+
+    db.DB.Model(&user).Updates(updates)
+    json.NewEncoder(w).Encode(user)
+}
+
+func DeleteUser(w http.ResponseWriter, r *http.Request) {
+    id := mux.Vars(r)["id"]
+    if err := db.DB.Delete(&models.User{}, id).Error; err != nil {
+        http.Error(w, "Error al eliminar usuario", http.StatusInternalServerError)
+        return
+    }
+    w.WriteHeader(http.StatusNoContent)
+}
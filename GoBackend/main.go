@@ -1,42 +1,103 @@
 package main
 
 import (
-    "log"
+    "context"
+    "log/slog"
     "net/http"
+    "os"
+    "strconv"
+    "time"
 
     "go-docs-backend/auth"
+    "go-docs-backend/cache"
     "go-docs-backend/db"
     "go-docs-backend/handlers"
     "go-docs-backend/models"
+    "go-docs-backend/oidc"
+    "go-docs-backend/requestid"
 
     "github.com/gorilla/mux"
 )
 
+// revocationSyncInterval define cada cuánto se refresca desde la base de
+// datos el set en memoria de jti revocados (ver auth.StartRevocationSync).
+const revocationSyncInterval = time.Minute
+
+const (
+    loginRateLimit = 5
+    apiRateLimit   = 100
+)
+
 func main() {
     db.Connect()
-    db.DB.AutoMigrate(&models.Document{})
+    db.DB.AutoMigrate(&models.Document{}, &models.User{}, &models.RefreshToken{}, &models.RevokedToken{}, &models.DocumentAuditLog{})
+    if err := db.MigrateFullTextSearch(); err != nil {
+        slog.Error("error al migrar el índice de búsqueda", "error", err)
+        os.Exit(1)
+    }
+
+    cache.Connect()
+    auth.StartRevocationSync(revocationSyncInterval)
+    oidc.Init(context.Background())
+
+    apiRateLimiter := cache.RateLimitMiddleware(apiRateLimit, time.Minute, "api", func(req *http.Request) string {
+        if claims, ok := auth.ClaimsFromContext(req.Context()); ok {
+            return strconv.FormatUint(uint64(claims.UserID), 10)
+        }
+        return cache.KeyByIP(req)
+    })
+    loginRateLimiter := cache.RateLimitMiddleware(loginRateLimit, time.Minute, "login", cache.KeyByIP)
 
     r := mux.NewRouter()
+    r.Use(requestid.Middleware)
 
-    // Login endpoint (no auth required)
-    r.HandleFunc("/login", handlers.LoginHandler).Methods("POST")
+    // Login, registro y refresh (no requieren un access token vigente)
+    r.Handle("/login", loginRateLimiter(http.HandlerFunc(handlers.LoginHandler))).Methods("POST")
+    r.HandleFunc("/register", handlers.RegisterHandler).Methods("POST")
+    r.HandleFunc("/auth/refresh", handlers.RefreshHandler).Methods("POST")
+
+    // Login social (OAuth2/OIDC): redirige al proveedor y procesa su
+    // callback, emitiendo el mismo formato de tokens que LoginHandler.
+    r.HandleFunc("/auth/{provider}/login", handlers.OIDCLoginHandler).Methods("GET")
+    r.HandleFunc("/auth/{provider}/callback", handlers.OIDCCallbackHandler).Methods("GET")
 
     // Protected routes with authentication
     protected := r.PathPrefix("/").Subrouter()
     protected.Use(auth.AuthMiddleware)
+    protected.Use(apiRateLimiter)
+
+    protected.HandleFunc("/auth/logout", handlers.LogoutHandler).Methods("POST")
 
-    // CRUD
-    protected.HandleFunc("/documents", handlers.CreateDocument).Methods("POST")
+    // CRUD (lectura abierta a cualquier usuario autenticado; escritura
+    // requiere rol editor o admin, y además queda acotada por la
+    // verificación de ownership dentro del handler)
     protected.HandleFunc("/documents", handlers.GetAllDocuments).Methods("GET")
     protected.HandleFunc("/documents/{id}", handlers.GetDocumentByID).Methods("GET")
-    protected.HandleFunc("/documents/{id}", handlers.UpdateDocument).Methods("PUT")
-    protected.HandleFunc("/documents/{id}", handlers.DeleteDocument).Methods("DELETE")
 
-    // Búsquedas
-    protected.HandleFunc("/search/keywords", handlers.SearchByKeyword).Methods("GET")
-    protected.HandleFunc("/search/title", handlers.SearchByTitle).Methods("GET")
-    protected.HandleFunc("/search/subtitle", handlers.SearchBySubtitle).Methods("GET")
+    documentWrites := protected.PathPrefix("/documents").Subrouter()
+    documentWrites.Use(auth.RequireRole(string(models.RoleAdmin), string(models.RoleEditor)))
+    documentWrites.HandleFunc("", handlers.CreateDocument).Methods("POST")
+    documentWrites.HandleFunc("/{id}", handlers.UpdateDocument).Methods("PUT")
+    documentWrites.HandleFunc("/{id}", handlers.DeleteDocument).Methods("DELETE")
+
+    // Búsqueda de texto completo
+    protected.HandleFunc("/search", handlers.Search).Methods("GET")
+
+    // Gestión de usuarios y auditoría, restringidas a administradores
+    users := protected.PathPrefix("/users").Subrouter()
+    users.Use(auth.RequireRole(string(models.RoleAdmin)))
+    users.HandleFunc("", handlers.CreateUser).Methods("POST")
+    users.HandleFunc("", handlers.GetAllUsers).Methods("GET")
+    users.HandleFunc("/{id}", handlers.UpdateUser).Methods("PUT")
+    users.HandleFunc("/{id}", handlers.DeleteUser).Methods("DELETE")
+
+    audit := protected.PathPrefix("/audit").Subrouter()
+    audit.Use(auth.RequireRole(string(models.RoleAdmin)))
+    audit.HandleFunc("", handlers.GetAuditLog).Methods("GET")
 
-    log.Println("Servidor corriendo en http://localhost:8080")
-    log.Fatal(http.ListenAndServe(":8080", r))
+    slog.Info("servidor corriendo", "addr", "http://localhost:8080")
+    if err := http.ListenAndServe(":8080", r); err != nil {
+        slog.Error("error en el servidor HTTP", "error", err)
+        os.Exit(1)
+    }
 }
@@ -0,0 +1,19 @@
+package models
+
+import (
+    "time"
+
+    "gorm.io/datatypes"
+)
+
+type DocumentAuditLog struct {
+    ID         uint           `gorm:"primaryKey" json:"id"`
+    UserID     uint           `json:"user_id"`
+    Action     string         `json:"action"`
+    DocumentID uint           `json:"document_id"`
+    Diff       datatypes.JSON `json:"diff"`
+    RequestID  string         `json:"request_id"`
+    IP         string         `json:"ip"`
+    UserAgent  string         `json:"user_agent"`
+    CreatedAt  time.Time      `json:"created_at"`
+}
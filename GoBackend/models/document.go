@@ -9,4 +9,5 @@ type Document struct {
     CreatedAt time.Time `json:"created_at"`
     Content   string    `json:"content"`
     Keywords  []string  `gorm:"type:text[]" json:"keywords"`
+    UserID    uint      `json:"user_id"`
 }
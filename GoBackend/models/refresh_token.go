@@ -0,0 +1,22 @@
+package models
+
+import "time"
+
+type RefreshToken struct {
+    ID        uint       `gorm:"primaryKey" json:"id"`
+    UserID    uint       `json:"user_id"`
+    TokenHash string     `gorm:"uniqueIndex;not null" json:"-"`
+    ExpiresAt time.Time  `json:"expires_at"`
+    RevokedAt *time.Time `json:"revoked_at"`
+    UserAgent string     `json:"user_agent"`
+    IP        string     `json:"ip"`
+    CreatedAt time.Time  `json:"created_at"`
+}
+
+// RevokedToken guarda los jti de access tokens invalidados antes de su
+// expiración natural (por ejemplo en logout), para que todas las
+// instancias del servicio los rechacen sin esperar el TTL del token.
+type RevokedToken struct {
+    JTI       string    `gorm:"primaryKey" json:"jti"`
+    RevokedAt time.Time `json:"revoked_at"`
+}
@@ -0,0 +1,20 @@
+package models
+
+import "time"
+
+type Role string
+
+const (
+    RoleAdmin  Role = "admin"
+    RoleEditor Role = "editor"
+    RoleReader Role = "reader"
+)
+
+type User struct {
+    ID           uint      `gorm:"primaryKey" json:"id"`
+    Username     string    `gorm:"uniqueIndex;not null" json:"username"`
+    Email        string    `gorm:"uniqueIndex;not null" json:"email"`
+    PasswordHash string    `gorm:"not null" json:"-"`
+    Role         Role      `gorm:"not null;default:reader" json:"role"`
+    CreatedAt    time.Time `json:"created_at"`
+}
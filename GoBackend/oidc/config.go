@@ -0,0 +1,67 @@
+package oidc
+
+import (
+    "os"
+    "strings"
+)
+
+// ProviderConfig describe un proveedor OIDC habilitado (Google o
+// cualquier emisor OIDC genérico). Todo proveedor pasa por discovery OIDC
+// y verificación de id_token: un proveedor solo OAuth2, sin documento de
+// discovery ni id_token (como GitHub), no llega a inicializarse.
+type ProviderConfig struct {
+    Name         string
+    IssuerURL    string
+    ClientID     string
+    ClientSecret string
+    RedirectURL  string
+    Scopes       []string
+}
+
+// defaultIssuers trae el issuer por defecto para los proveedores
+// conocidos; uno genérico requiere declarar el suyo explícitamente vía
+// OIDC_<NOMBRE>_ISSUER_URL.
+var defaultIssuers = map[string]string{
+    "google": "https://accounts.google.com",
+}
+
+// LoadProviderConfigs lee OIDC_PROVIDERS (lista separada por comas de
+// proveedores activos, p. ej. "google,okta") y arma la configuración de
+// cada uno desde OIDC_<NOMBRE>_ISSUER_URL, _CLIENT_ID, _CLIENT_SECRET y
+// _REDIRECT_URL. Un proveedor sin client_id/client_secret configurados
+// queda en el mapa igual; NewProvider fallará al inicializarlo y el
+// error se loguea sin tumbar el servidor (ver Init).
+func LoadProviderConfigs() map[string]ProviderConfig {
+    configs := make(map[string]ProviderConfig)
+
+    names := getEnvOrDefault("OIDC_PROVIDERS", "")
+    if names == "" {
+        return configs
+    }
+
+    for _, name := range strings.Split(names, ",") {
+        name = strings.TrimSpace(strings.ToLower(name))
+        if name == "" {
+            continue
+        }
+
+        prefix := "OIDC_" + strings.ToUpper(name) + "_"
+        configs[name] = ProviderConfig{
+            Name:         name,
+            IssuerURL:    getEnvOrDefault(prefix+"ISSUER_URL", defaultIssuers[name]),
+            ClientID:     os.Getenv(prefix + "CLIENT_ID"),
+            ClientSecret: os.Getenv(prefix + "CLIENT_SECRET"),
+            RedirectURL:  os.Getenv(prefix + "REDIRECT_URL"),
+            Scopes:       []string{"openid", "email", "profile"},
+        }
+    }
+
+    return configs
+}
+
+func getEnvOrDefault(key, defaultValue string) string {
+    if value := os.Getenv(key); value != "" {
+        return value
+    }
+    return defaultValue
+}
@@ -0,0 +1,104 @@
+package oidc
+
+import (
+    "crypto/rand"
+    "crypto/sha256"
+    "encoding/base64"
+    "encoding/hex"
+    "errors"
+    "net/http"
+    "time"
+
+    "github.com/golang-jwt/jwt/v5"
+)
+
+var flowSecret = []byte(getEnvOrDefault("OIDC_FLOW_SECRET", "oidc-flow-secret"))
+
+const (
+    flowCookieName = "oidc_flow"
+    flowTTL        = 10 * time.Minute
+)
+
+// flowClaims viaja firmado (no cifrado) dentro de la cookie de estado:
+// no lleva nada que no podamos revelar al navegador del usuario.
+type flowClaims struct {
+    State        string `json:"state"`
+    CodeVerifier string `json:"code_verifier"`
+    Provider     string `json:"provider"`
+    jwt.RegisteredClaims
+}
+
+// StartFlow genera el state y el code_verifier/code_challenge PKCE para
+// provider, los guarda en una cookie firmada de corta vida y devuelve la
+// URL de autorización a la que redirigir al usuario.
+func StartFlow(w http.ResponseWriter, provider *Provider) (string, error) {
+    state, err := randomToken()
+    if err != nil {
+        return "", err
+    }
+    verifier, err := randomToken()
+    if err != nil {
+        return "", err
+    }
+
+    claims := flowClaims{
+        State:        state,
+        CodeVerifier: verifier,
+        Provider:     provider.Name,
+        RegisteredClaims: jwt.RegisteredClaims{
+            ExpiresAt: jwt.NewNumericDate(time.Now().Add(flowTTL)),
+        },
+    }
+    signed, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(flowSecret)
+    if err != nil {
+        return "", err
+    }
+
+    http.SetCookie(w, &http.Cookie{
+        Name:     flowCookieName,
+        Value:    signed,
+        Path:     "/auth/" + provider.Name,
+        HttpOnly: true,
+        Secure:   true,
+        SameSite: http.SameSiteLaxMode,
+        MaxAge:   int(flowTTL.Seconds()),
+    })
+
+    return provider.AuthCodeURL(state, codeChallengeS256(verifier)), nil
+}
+
+// CompleteFlow valida la cookie de estado emitida por StartFlow contra
+// el state devuelto por el proveedor en el callback y devuelve el
+// code_verifier original para el intercambio del código por tokens.
+func CompleteFlow(r *http.Request, providerName, state string) (codeVerifier string, err error) {
+    cookie, err := r.Cookie(flowCookieName)
+    if err != nil {
+        return "", err
+    }
+
+    claims := &flowClaims{}
+    if _, err := jwt.ParseWithClaims(cookie.Value, claims, func(*jwt.Token) (interface{}, error) {
+        return flowSecret, nil
+    }); err != nil {
+        return "", err
+    }
+
+    if claims.Provider != providerName || claims.State != state {
+        return "", errors.New("state inválido o no coincide con el proveedor")
+    }
+
+    return claims.CodeVerifier, nil
+}
+
+func randomToken() (string, error) {
+    buf := make([]byte, 32)
+    if _, err := rand.Read(buf); err != nil {
+        return "", err
+    }
+    return hex.EncodeToString(buf), nil
+}
+
+func codeChallengeS256(verifier string) string {
+    sum := sha256.Sum256([]byte(verifier))
+    return base64.RawURLEncoding.EncodeToString(sum[:])
+}
@@ -0,0 +1,85 @@
+package oidc
+
+import (
+    "context"
+    "errors"
+
+    "github.com/coreos/go-oidc/v3/oidc"
+    "golang.org/x/oauth2"
+)
+
+// Provider envuelve la configuración OAuth2 y el verificador de ID
+// tokens de un proveedor OIDC ya resuelto vía discovery.
+type Provider struct {
+    Name         string
+    verifier     *oidc.IDTokenVerifier
+    oauth2Config oauth2.Config
+}
+
+// NewProvider resuelve el documento de descubrimiento OIDC del emisor y
+// arma el cliente OAuth2 y el verificador de ID tokens para cfg.
+func NewProvider(ctx context.Context, cfg ProviderConfig) (*Provider, error) {
+    if cfg.ClientID == "" || cfg.ClientSecret == "" {
+        return nil, errors.New("client_id y client_secret son requeridos")
+    }
+
+    p, err := oidc.NewProvider(ctx, cfg.IssuerURL)
+    if err != nil {
+        return nil, err
+    }
+
+    return &Provider{
+        Name:     cfg.Name,
+        verifier: p.Verifier(&oidc.Config{ClientID: cfg.ClientID}),
+        oauth2Config: oauth2.Config{
+            ClientID:     cfg.ClientID,
+            ClientSecret: cfg.ClientSecret,
+            RedirectURL:  cfg.RedirectURL,
+            Endpoint:     p.Endpoint(),
+            Scopes:       cfg.Scopes,
+        },
+    }, nil
+}
+
+// AuthCodeURL arma la URL de autorización para state y el code_challenge
+// PKCE (S256) derivado del code_verifier generado en StartFlow.
+func (p *Provider) AuthCodeURL(state, codeChallenge string) string {
+    return p.oauth2Config.AuthCodeURL(state,
+        oauth2.SetAuthURLParam("code_challenge", codeChallenge),
+        oauth2.SetAuthURLParam("code_challenge_method", "S256"))
+}
+
+// Exchange cambia el código de autorización por tokens, incluyendo el
+// code_verifier PKCE que el proveedor exige para validar el intercambio.
+func (p *Provider) Exchange(ctx context.Context, code, codeVerifier string) (*oauth2.Token, error) {
+    return p.oauth2Config.Exchange(ctx, code, oauth2.SetAuthURLParam("code_verifier", codeVerifier))
+}
+
+// Claims son los claims del ID token que nos importan para vincular o
+// aprovisionar el User local.
+type Claims struct {
+    Email         string `json:"email"`
+    EmailVerified bool   `json:"email_verified"`
+    Name          string `json:"name"`
+}
+
+// VerifyIDToken valida la firma, el issuer y la audiencia del id_token
+// devuelto junto al token y devuelve sus claims.
+func (p *Provider) VerifyIDToken(ctx context.Context, token *oauth2.Token) (*Claims, error) {
+    raw, ok := token.Extra("id_token").(string)
+    if !ok {
+        return nil, errors.New("el proveedor no devolvió un id_token")
+    }
+
+    idToken, err := p.verifier.Verify(ctx, raw)
+    if err != nil {
+        return nil, err
+    }
+
+    var claims Claims
+    if err := idToken.Claims(&claims); err != nil {
+        return nil, err
+    }
+
+    return &claims, nil
+}
@@ -0,0 +1,29 @@
+package oidc
+
+import (
+    "context"
+    "log/slog"
+)
+
+var providers = make(map[string]*Provider)
+
+// Init resuelve un Provider por cada configuración devuelta por
+// LoadProviderConfigs. Un proveedor cuya inicialización falla (issuer mal
+// configurado, client_id vacío, proveedor sin endpoint OIDC real) se
+// omite y se loguea: el resto del servidor sigue arrancando igual.
+func Init(ctx context.Context) {
+    for name, cfg := range LoadProviderConfigs() {
+        provider, err := NewProvider(ctx, cfg)
+        if err != nil {
+            slog.Error("oidc: no se pudo inicializar el proveedor", "provider", name, "error", err)
+            continue
+        }
+        providers[name] = provider
+    }
+}
+
+// Get devuelve el Provider ya inicializado para name, si existe.
+func Get(name string) (*Provider, bool) {
+    p, ok := providers[name]
+    return p, ok
+}
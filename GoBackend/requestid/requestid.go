@@ -0,0 +1,47 @@
+package requestid
+
+import (
+    "context"
+    "crypto/rand"
+    "encoding/hex"
+    "net/http"
+)
+
+type contextKey string
+
+const idContextKey contextKey = "request_id"
+
+// HeaderName es el header usado tanto para leer un ID de correlación
+// provisto por el cliente como para devolverlo en la respuesta.
+const HeaderName = "X-Request-ID"
+
+// Middleware asigna un ID de correlación a cada request (reutilizando el
+// que venga en el header X-Request-ID, si lo hay), lo inyecta en el
+// contexto para que handlers y logs lo usen, y lo refleja en la
+// respuesta.
+func Middleware(next http.Handler) http.Handler {
+    return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        id := r.Header.Get(HeaderName)
+        if id == "" {
+            id = newID()
+        }
+
+        w.Header().Set(HeaderName, id)
+        ctx := context.WithValue(r.Context(), idContextKey, id)
+        next.ServeHTTP(w, r.WithContext(ctx))
+    })
+}
+
+// FromContext recupera el ID de correlación inyectado por Middleware.
+func FromContext(ctx context.Context) string {
+    id, _ := ctx.Value(idContextKey).(string)
+    return id
+}
+
+func newID() string {
+    buf := make([]byte, 16)
+    if _, err := rand.Read(buf); err != nil {
+        return ""
+    }
+    return hex.EncodeToString(buf)
+}